@@ -0,0 +1,52 @@
+// Pre-1.7 versions don't read assets from the objects/ hash store directly;
+// they expect them laid out by logical path, either under
+// assets/virtual/legacy/ (the "virtual" asset index flag) or under a
+// resources/ directory next to the game dir (the "map_to_resources" flag).
+// This file builds those layouts by copying each already-downloaded object
+// to its logical path.
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// materializeLegacy copies every object referenced by objects into destDir,
+// using each map key as the path relative to destDir.
+func materializeLegacy(assetsDir, destDir string, objects map[string]AssetObject) error {
+	for assetPath, obj := range objects {
+		src := objectPath(assetsDir, obj.Hash)
+		dst := filepath.Join(destDir, filepath.FromSlash(assetPath))
+
+		if ok, _ := fileMatchesHash(dst, obj.Hash); ok {
+			continue
+		}
+		if err := copyFile(src, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies src to dst, creating dst's parent directory if needed.
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
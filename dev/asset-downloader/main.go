@@ -0,0 +1,325 @@
+// Minecraft Asset Downloader.
+// This tool resolves a version's asset index and downloads every asset it
+// references into the shared assets directory, verifying each file's SHA-1
+// along the way.
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AssetObject is one entry of an asset index's `objects` map: a logical
+// asset path (e.g. "minecraft/sounds/random/pop.ogg") mapped to its hash
+// and size.
+type AssetObject struct {
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+}
+
+// AssetIndex is the JSON document an `assetIndex.url` points to.
+type AssetIndex struct {
+	Objects        map[string]AssetObject `json:"objects"`
+	Virtual        bool                   `json:"virtual,omitempty"`          // pre-1.7: also lay out assets/virtual/legacy/<path>
+	MapToResources bool                   `json:"map_to_resources,omitempty"` // very old alpha/beta: also lay out resources/<path>
+}
+
+// versionCacheEntry is the subset of version-analyzer's normalized
+// per-version JSON this tool needs.
+type versionCacheEntry struct {
+	AssetIndexURL string `json:"assetIndexUrl"`
+	AssetIndexID  string `json:"assetIndexId"`
+}
+
+// downloadResult is handed back from a worker for one object.
+type downloadResult struct {
+	path string
+	err  error
+}
+
+const (
+	maxRetries  = 5
+	baseBackoff = 500 * time.Millisecond
+)
+
+func main() {
+	versionJSONPath := flag.String("version-json", "", "path to a version-analyzer normalized version JSON (provides assetIndexUrl/assetIndexId)")
+	assetIndexURL := flag.String("asset-index-url", "", "asset index URL, alternative to -version-json")
+	assetIndexID := flag.String("asset-index-id", "", "asset index id, required with -asset-index-url")
+	assetsDir := flag.String("assets-dir", "assets", "base assets directory (objects/, indexes/ and virtual/ are created under it; resources/ is created in the working directory)")
+	workers := flag.Int("workers", 8, "number of parallel download workers")
+	verifyOnly := flag.Bool("verify-only", false, "re-hash existing object files instead of downloading, report corruption")
+	flag.Parse()
+
+	if *verifyOnly {
+		runVerifyOnly(*assetsDir)
+		return
+	}
+
+	url, id := *assetIndexURL, *assetIndexID
+	if *versionJSONPath != "" {
+		var err error
+		url, id, err = readVersionCache(*versionJSONPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "read version json: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if url == "" || id == "" {
+		fmt.Fprintln(os.Stderr, "usage: either -version-json, or both -asset-index-url and -asset-index-id must be set")
+		os.Exit(1)
+	}
+
+	index, err := fetchAssetIndex(url)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fetch asset index: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeAssetIndex(*assetsDir, id, index); err != nil {
+		fmt.Fprintf(os.Stderr, "write asset index: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Downloading %d assets with %d workers...\n", len(index.Objects), *workers)
+
+	failed := downloadObjects(*assetsDir, index.Objects, *workers)
+
+	if index.Virtual {
+		fmt.Println("Materializing virtual/legacy layout...")
+		if err := materializeLegacy(*assetsDir, filepath.Join(*assetsDir, "virtual", "legacy"), index.Objects); err != nil {
+			fmt.Fprintf(os.Stderr, "materialize virtual/legacy: %v\n", err)
+		}
+	}
+	if index.MapToResources {
+		fmt.Println("Materializing resources layout...")
+		if err := materializeLegacy(*assetsDir, "resources", index.Objects); err != nil {
+			fmt.Fprintf(os.Stderr, "materialize resources: %v\n", err)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("Done, %d asset(s) failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("Done")
+}
+
+// readVersionCache reads a version-analyzer normalized version JSON and
+// returns its asset index URL and id.
+func readVersionCache(path string) (url, id string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	var v versionCacheEntry
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", "", err
+	}
+	return v.AssetIndexURL, v.AssetIndexID, nil
+}
+
+// fetchAssetIndex downloads and parses the asset index JSON.
+func fetchAssetIndex(url string) (*AssetIndex, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var index AssetIndex
+	if err := json.Unmarshal(body, &index); err != nil {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// writeAssetIndex re-encodes the parsed asset index and saves it under
+// assets/indexes/<id>.json, mirroring the vanilla launcher's layout.
+func writeAssetIndex(assetsDir, id string, index *AssetIndex) error {
+	dir := filepath.Join(assetsDir, "indexes")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, id+".json"), data, 0o644)
+}
+
+// objectPath returns the on-disk path for an asset with the given hash:
+// assets/objects/<first 2 hex chars>/<hash>.
+func objectPath(assetsDir, hash string) string {
+	return filepath.Join(assetsDir, "objects", hash[:2], hash)
+}
+
+// downloadObjects fans the objects out across workers goroutines and
+// returns how many failed after exhausting retries.
+func downloadObjects(assetsDir string, objects map[string]AssetObject, workers int) int {
+	jobs := make(chan AssetObject)
+	var failed int64
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for obj := range jobs {
+				if err := downloadObject(assetsDir, obj); err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", obj.Hash, err)
+					atomic.AddInt64(&failed, 1)
+				}
+			}
+		}()
+	}
+
+	for _, obj := range objects {
+		jobs <- obj
+	}
+	close(jobs)
+	wg.Wait()
+
+	return int(failed)
+}
+
+// downloadObject ensures a single asset object exists on disk with a
+// matching SHA-1, resuming a partially downloaded file and retrying
+// transient failures with exponential backoff.
+func downloadObject(assetsDir string, obj AssetObject) error {
+	path := objectPath(assetsDir, obj.Hash)
+
+	if ok, _ := fileMatchesHash(path, obj.Hash); ok {
+		return nil
+	}
+
+	url := fmt.Sprintf("https://resources.download.minecraft.net/%s/%s", obj.Hash[:2], obj.Hash)
+
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(baseBackoff * time.Duration(1<<uint(attempt-1)))
+		}
+
+		if err := downloadToFile(url, path); err != nil {
+			lastErr = err
+			continue
+		}
+
+		if ok, err := fileMatchesHash(path, obj.Hash); ok {
+			return nil
+		} else if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("sha1 mismatch after download")
+		}
+		os.Remove(path) // corrupt or partial, don't let a resume pick it up
+	}
+	return fmt.Errorf("after %d attempts: %w", maxRetries, lastErr)
+}
+
+// downloadToFile streams url into path, resuming from the end of an
+// existing partial file via a Range request where the server honors one.
+func downloadToFile(url, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	var resumeFrom int64
+	if fi, err := os.Stat(path); err == nil {
+		resumeFrom = fi.Size()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if resumeFrom > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if resp.StatusCode == http.StatusPartialContent {
+		flags |= os.O_APPEND
+	} else {
+		// Server ignored the Range header (or we had nothing to resume):
+		// start the file over.
+		flags |= os.O_TRUNC
+	}
+
+	out, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+// fileMatchesHash reports whether the file at path exists and its SHA-1
+// matches hash.
+func fileMatchesHash(path, hash string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, nil
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false, err
+	}
+	return hex.EncodeToString(h.Sum(nil)) == hash, nil
+}
+
+// runVerifyOnly re-hashes every object already on disk under assetsDir and
+// reports any whose content no longer matches its filename (the hash).
+func runVerifyOnly(assetsDir string) {
+	root := filepath.Join(assetsDir, "objects")
+	corrupt := 0
+	checked := 0
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		hash := info.Name()
+		checked++
+		if ok, _ := fileMatchesHash(path, hash); !ok {
+			fmt.Printf("CORRUPT  %s\n", path)
+			corrupt++
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "walk %s: %v\n", root, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Checked %d object(s), %d corrupt\n", checked, corrupt)
+	if corrupt > 0 {
+		os.Exit(1)
+	}
+}
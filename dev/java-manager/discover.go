@@ -0,0 +1,142 @@
+// Locating an already-installed JDK/JRE of a given major version: probe
+// the well-known places first, then ask each candidate `java` binary what
+// version it actually is.
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// javaVersionProperty matches the `java.version` line from
+// `java -XshowSettings:properties -version`, e.g. "    java.version = 21.0.2".
+var javaVersionProperty = regexp.MustCompile(`^\s*java\.version\s*=\s*(\S+)`)
+
+// locateInstalledJDK probes JAVA_HOME, PATH, and platform-specific install
+// locations for a `java` binary reporting the requested major version.
+func locateInstalledJDK(major int) (string, bool) {
+	for _, candidate := range candidateBinaries(major) {
+		if javaBinaryWorks(candidate, major) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// candidateBinaries lists java binaries worth probing, in priority order:
+// JAVA_HOME, PATH, then platform-specific JDK install directories.
+func candidateBinaries(major int) []string {
+	exe := "java"
+	if runtime.GOOS == "windows" {
+		exe = "java.exe"
+	}
+
+	var candidates []string
+	if home := os.Getenv("JAVA_HOME"); home != "" {
+		candidates = append(candidates, filepath.Join(home, "bin", exe))
+	}
+	if path, err := exec.LookPath("java"); err == nil {
+		candidates = append(candidates, path)
+	}
+
+	for _, dir := range platformJDKDirs(major) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			candidates = append(candidates, filepath.Join(dir, e.Name(), javaHomeSuffix, "bin", exe))
+		}
+	}
+
+	return candidates
+}
+
+// javaHomeSuffix is the path segment between a scanned JDK install
+// directory and its actual home: on macOS, /Library/Java/JavaVirtualMachines
+// holds "<name>.jdk" bundles whose real JAVA_HOME is Contents/Home inside
+// them; everywhere else the install directory is the home itself.
+var javaHomeSuffix = func() string {
+	if runtime.GOOS == "darwin" {
+		return filepath.Join("Contents", "Home")
+	}
+	return ""
+}()
+
+// platformJDKDirs returns the directories this OS conventionally installs
+// JDKs under. They're scanned rather than addressed directly because the
+// per-vendor subdirectory name (e.g. "jdk-21.0.2", "temurin-17-jdk") varies.
+func platformJDKDirs(major int) []string {
+	switch runtime.GOOS {
+	case "windows":
+		return []string{`C:\Program Files\Java`, `C:\Program Files (x86)\Java`}
+	case "darwin":
+		return []string{"/Library/Java/JavaVirtualMachines"}
+	default:
+		return []string{"/usr/lib/jvm"}
+	}
+}
+
+// javaBinaryWorks reports whether path is a runnable java binary reporting
+// the given major version.
+func javaBinaryWorks(path string, major int) bool {
+	if path == "" {
+		return false
+	}
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+
+	got, ok := probeJavaMajorVersion(path)
+	return ok && got == major
+}
+
+// probeJavaMajorVersion runs `java -XshowSettings:properties -version` and
+// parses the reported java.version into a major version number.
+func probeJavaMajorVersion(javaPath string) (int, bool) {
+	cmd := exec.Command(javaPath, "-XshowSettings:properties", "-version")
+	// This tool prints its output to stderr, not stdout.
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, false
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		if m := javaVersionProperty.FindStringSubmatch(scanner.Text()); m != nil {
+			return parseJavaMajorVersion(m[1])
+		}
+	}
+	return 0, false
+}
+
+// parseJavaMajorVersion converts a java.version string into its major
+// version number, handling both the modern scheme ("17.0.9" -> 17) and the
+// legacy "1.X" scheme used up to Java 8 ("1.8.0_392" -> 8).
+func parseJavaMajorVersion(version string) (int, bool) {
+	parts := strings.Split(version, ".")
+	if len(parts) == 0 {
+		return 0, false
+	}
+
+	first, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, false
+	}
+
+	if first == 1 && len(parts) > 1 {
+		second, err := strconv.Atoi(strings.Split(parts[1], "_")[0])
+		if err != nil {
+			return 0, false
+		}
+		return second, true
+	}
+
+	return first, true
+}
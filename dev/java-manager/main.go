@@ -0,0 +1,117 @@
+// Java Runtime Manager.
+// Finds a JDK/JRE of the major version a Minecraft version needs, or
+// downloads one from Mojang's own runtime distribution, so the launcher
+// never has to assume a suitable `java` is already on PATH.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// versionCacheEntry is the subset of version-analyzer's normalized
+// per-version JSON this tool needs.
+type versionCacheEntry struct {
+	JavaMajorVersion int `json:"javaMajorVersion"`
+}
+
+func main() {
+	versionJSONPath := flag.String("version-json", "", "path to a version-analyzer normalized version JSON (provides javaMajorVersion)")
+	major := flag.Int("major", 0, "required Java major version, alternative to -version-json")
+	cachePath := flag.String("cache", "java-cache.json", "where discovered/installed runtime paths are persisted")
+	installDir := flag.String("install-dir", "runtimes", "base directory to install downloaded runtimes into")
+	flag.Parse()
+
+	want := *major
+	if *versionJSONPath != "" {
+		v, err := readVersionCache(*versionJSONPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "read version json: %v\n", err)
+			os.Exit(1)
+		}
+		want = v.JavaMajorVersion
+	}
+	if want == 0 {
+		fmt.Fprintln(os.Stderr, "usage: either -version-json or -major must be set")
+		os.Exit(1)
+	}
+
+	cache, err := loadCache(*cachePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load cache: %v\n", err)
+		os.Exit(1)
+	}
+
+	if path, ok := cache[want]; ok {
+		if javaBinaryWorks(path, want) {
+			fmt.Println(path)
+			return
+		}
+		// Stale entry: the binary moved or was removed underneath us.
+		delete(cache, want)
+	}
+
+	if path, ok := locateInstalledJDK(want); ok {
+		cache[want] = path
+		if err := saveCache(*cachePath, cache); err != nil {
+			fmt.Fprintf(os.Stderr, "save cache: %v\n", err)
+		}
+		fmt.Println(path)
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "no Java %d found, downloading a runtime...\n", want)
+	path, err := provisionRuntime(want, *installDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "provision Java %d: %v\n", want, err)
+		os.Exit(1)
+	}
+
+	cache[want] = path
+	if err := saveCache(*cachePath, cache); err != nil {
+		fmt.Fprintf(os.Stderr, "save cache: %v\n", err)
+	}
+	fmt.Println(path)
+}
+
+// readVersionCache reads a version-analyzer normalized version JSON.
+func readVersionCache(path string) (*versionCacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var v versionCacheEntry
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// loadCache reads the major-version -> java binary path cache, returning
+// an empty map if it doesn't exist yet.
+func loadCache(path string) (map[int]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[int]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cache map[int]string
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// saveCache persists the major-version -> java binary path cache.
+func saveCache(path string, cache map[int]string) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
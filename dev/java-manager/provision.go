@@ -0,0 +1,244 @@
+// Downloading a Java runtime straight from Mojang when no suitable JDK is
+// already installed, using the same manifest the vanilla launcher uses.
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// javaRuntimeManifestURL is Mojang's index of available Java runtimes per
+// platform and component.
+const javaRuntimeManifestURL = "https://launchermeta.mojang.com/v1/products/java-runtime/2ec0cc96c44e5a76b9c8b7c39df7210883d12871/all.json"
+
+// runtimeManifest is the top-level document at javaRuntimeManifestURL:
+// platform name -> component name -> available builds.
+type runtimeManifest map[string]map[string][]runtimeBuild
+
+// runtimeBuild is one available build of a runtime component.
+type runtimeBuild struct {
+	Manifest struct {
+		URL  string `json:"url"`
+		SHA1 string `json:"sha1"`
+	} `json:"manifest"`
+	Version struct {
+		Name string `json:"name"`
+	} `json:"version"`
+}
+
+// runtimeFilesManifest is the per-build file listing a runtimeBuild.Manifest.URL points to.
+type runtimeFilesManifest struct {
+	Files map[string]runtimeFileEntry `json:"files"`
+}
+
+// runtimeFileEntry is one file or directory in a runtime build.
+type runtimeFileEntry struct {
+	Type       string `json:"type"` // "file", "directory" or "link"
+	Executable bool   `json:"executable,omitempty"`
+	Downloads  *struct {
+		Raw struct {
+			URL  string `json:"url"`
+			SHA1 string `json:"sha1"`
+			Size int64  `json:"size"`
+		} `json:"raw"`
+	} `json:"downloads,omitempty"`
+}
+
+// componentForMajor maps a Java major version to the runtime component
+// name Mojang publishes it under.
+func componentForMajor(major int) (string, bool) {
+	switch {
+	case major <= 8:
+		return "jre-legacy", true
+	case major <= 16:
+		return "java-runtime-alpha", true
+	case major <= 17:
+		return "java-runtime-gamma", true
+	default:
+		return "java-runtime-delta", true
+	}
+}
+
+// platformKey returns the key this host's platform is listed under in the
+// Java runtime manifest.
+func platformKey() (string, bool) {
+	switch runtime.GOOS {
+	case "linux":
+		if runtime.GOARCH == "arm64" {
+			return "linux-arm64", true
+		}
+		return "linux", true
+	case "darwin":
+		if runtime.GOARCH == "arm64" {
+			return "mac-os-arm64", true
+		}
+		return "mac-os", true
+	case "windows":
+		if runtime.GOARCH == "386" {
+			return "windows-x86", true
+		}
+		return "windows-x64", true
+	default:
+		return "", false
+	}
+}
+
+// javaBinaryRelPath returns where a provisioned runtime's java binary ends
+// up relative to destRoot. Mojang's mac-os/mac-os-arm64 runtime files are
+// laid out as a bundle, jre.bundle/Contents/Home/bin/java, instead of the
+// bin/java used on linux and windows.
+func javaBinaryRelPath() string {
+	exe := "java"
+	if runtime.GOOS == "windows" {
+		exe = "java.exe"
+	}
+	if runtime.GOOS == "darwin" {
+		return filepath.Join("jre.bundle", "Contents", "Home", "bin", exe)
+	}
+	return filepath.Join("bin", exe)
+}
+
+// provisionRuntime downloads the runtime component matching major for this
+// host into <installDir>/<major>, verifying every file's SHA-1, and
+// returns the path to its java binary.
+func provisionRuntime(major int, installDir string) (string, error) {
+	component, ok := componentForMajor(major)
+	if !ok {
+		return "", fmt.Errorf("no known runtime component for Java %d", major)
+	}
+	platform, ok := platformKey()
+	if !ok {
+		return "", fmt.Errorf("unsupported platform %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+
+	manifest, err := fetchJSON[runtimeManifest](javaRuntimeManifestURL)
+	if err != nil {
+		return "", fmt.Errorf("fetch runtime manifest: %w", err)
+	}
+
+	builds := manifest[platform][component]
+	if len(builds) == 0 {
+		return "", fmt.Errorf("no %s build published for %s", component, platform)
+	}
+	build := builds[0]
+
+	files, err := fetchJSON[runtimeFilesManifest](build.Manifest.URL)
+	if err != nil {
+		return "", fmt.Errorf("fetch runtime file list: %w", err)
+	}
+
+	destRoot := filepath.Join(installDir, fmt.Sprintf("%d", major))
+	for path, entry := range files.Files {
+		if err := materializeRuntimeFile(destRoot, path, entry); err != nil {
+			return "", fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	javaBinary := filepath.Join(destRoot, javaBinaryRelPath())
+	if _, err := os.Stat(javaBinary); err != nil {
+		return "", fmt.Errorf("provisioned runtime missing %s: %w", javaBinary, err)
+	}
+	return javaBinary, nil
+}
+
+// materializeRuntimeFile creates a single entry (directory or downloaded
+// file) of a runtime build under destRoot.
+func materializeRuntimeFile(destRoot, relPath string, entry runtimeFileEntry) error {
+	dest := filepath.Join(destRoot, filepath.FromSlash(relPath))
+
+	switch entry.Type {
+	case "directory":
+		return os.MkdirAll(dest, 0o755)
+	case "link":
+		return nil // symlinks inside runtime archives aren't load-bearing for launching java
+	case "file":
+		if entry.Downloads == nil {
+			return fmt.Errorf("file entry missing downloads.raw")
+		}
+		if ok, _ := fileMatchesSHA1(dest, entry.Downloads.Raw.SHA1); ok {
+			return nil
+		}
+		if err := downloadFile(entry.Downloads.Raw.URL, dest); err != nil {
+			return err
+		}
+		if ok, err := fileMatchesSHA1(dest, entry.Downloads.Raw.SHA1); !ok {
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("sha1 mismatch after download")
+		}
+		if entry.Executable {
+			return os.Chmod(dest, 0o755)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown runtime file type %q", entry.Type)
+	}
+}
+
+// downloadFile downloads url to dest, creating dest's parent directory.
+func downloadFile(url, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+// fileMatchesSHA1 reports whether the file at path exists and its SHA-1
+// matches hash.
+func fileMatchesSHA1(path, hash string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, nil
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false, err
+	}
+	return hex.EncodeToString(h.Sum(nil)) == hash, nil
+}
+
+// fetchJSON downloads url and unmarshals it into a value of type T.
+func fetchJSON[T any](url string) (T, error) {
+	var zero T
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return zero, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return zero, err
+	}
+
+	var v T
+	if err := json.Unmarshal(body, &v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
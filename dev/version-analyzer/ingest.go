@@ -0,0 +1,333 @@
+// Per-version ingestion: downloads each version's own JSON from Mojang,
+// evaluates its conditional rules for the current host, and writes a
+// normalized summary that the Rust launcher can load without having to
+// understand Mojang's manifest schema itself.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/FrogdreamStudios/launcher/dev/version-analyzer/rule"
+)
+
+// currentRuntimeContext builds a rule.RuntimeContext for the host this tool
+// is running on. Feature flags default to false; the launcher overrides
+// them per-launch (demo account, custom resolution, quick play, ...).
+func currentRuntimeContext() rule.RuntimeContext {
+	osName := "linux"
+	switch runtime.GOOS {
+	case "windows":
+		osName = "windows"
+	case "darwin":
+		osName = "osx"
+	}
+
+	arch := runtime.GOARCH
+	if arch == "amd64" {
+		arch = "x86_64"
+	}
+
+	return rule.RuntimeContext{OS: osName, Arch: arch, Features: map[string]bool{}}
+}
+
+// archBits returns the "32"/"64" bit-width token old LWJGL-2 natives
+// classifiers template into their name as "${arch}".
+func archBits(arch string) string {
+	if arch == "x86" {
+		return "32"
+	}
+	return "64"
+}
+
+// jsonOS mirrors rule.OS with JSON tags; Mojang's version JSON shape.
+type jsonOS struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	Arch    string `json:"arch,omitempty"`
+}
+
+// jsonRule mirrors rule.Rule with JSON tags.
+type jsonRule struct {
+	Action   string          `json:"action"`
+	OS       *jsonOS         `json:"os,omitempty"`
+	Features map[string]bool `json:"features,omitempty"`
+}
+
+// toRule converts the JSON representation into rule.Rule.
+func (jr jsonRule) toRule() rule.Rule {
+	r := rule.Rule{Action: jr.Action, Features: jr.Features}
+	if jr.OS != nil {
+		r.OS = &rule.OS{Name: jr.OS.Name, Version: jr.OS.Version, Arch: jr.OS.Arch}
+	}
+	return r
+}
+
+// toRules converts a slice of jsonRule into []rule.Rule.
+func toRules(rules []jsonRule) []rule.Rule {
+	out := make([]rule.Rule, len(rules))
+	for i, r := range rules {
+		out[i] = r.toRule()
+	}
+	return out
+}
+
+// versionArgument is a single `arguments.game`/`arguments.jvm` entry. Mojang
+// represents it either as a bare string, or as an object with rules plus a
+// value that is itself a string or an array of strings.
+type versionArgument struct {
+	Rules []jsonRule
+	Value []string
+}
+
+// UnmarshalJSON accepts both shapes found in Mojang version JSON.
+func (a *versionArgument) UnmarshalJSON(data []byte) error {
+	var plain string
+	if err := json.Unmarshal(data, &plain); err == nil {
+		a.Value = []string{plain}
+		return nil
+	}
+
+	var obj struct {
+		Rules []jsonRule      `json:"rules"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	a.Rules = obj.Rules
+
+	var single string
+	if err := json.Unmarshal(obj.Value, &single); err == nil {
+		a.Value = []string{single}
+		return nil
+	}
+	return json.Unmarshal(obj.Value, &a.Value)
+}
+
+// artifact is a downloadable file entry (library jar, native, asset index).
+type artifact struct {
+	Path string `json:"path,omitempty"`
+	SHA1 string `json:"sha1"`
+	Size int64  `json:"size"`
+	URL  string `json:"url"`
+}
+
+// versionLibrary is one entry of the top-level `libraries` array.
+type versionLibrary struct {
+	Name      string `json:"name"`
+	Downloads struct {
+		Artifact    *artifact           `json:"artifact,omitempty"`
+		Classifiers map[string]artifact `json:"classifiers,omitempty"`
+	} `json:"downloads"`
+	Natives map[string]string `json:"natives,omitempty"` // os -> classifier key, e.g. "linux": "natives-linux"
+	Rules   []jsonRule        `json:"rules,omitempty"`
+}
+
+// versionJSON is the subset of a per-version Mojang JSON this tool needs.
+type versionJSON struct {
+	Arguments struct {
+		Game []versionArgument `json:"game"`
+		JVM  []versionArgument `json:"jvm"`
+	} `json:"arguments"`
+	AssetIndex struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	} `json:"assetIndex"`
+	JavaVersion struct {
+		MajorVersion int `json:"majorVersion"`
+	} `json:"javaVersion"`
+	Libraries []versionLibrary `json:"libraries"`
+}
+
+// normalizedLibrary is one resolved, download-ready library entry.
+type normalizedLibrary struct {
+	Name   string  `json:"name"`
+	URL    string  `json:"url"`
+	SHA1   string  `json:"sha1"`
+	Size   int64   `json:"size"`
+	Native *string `json:"native,omitempty"` // target OS, set only for native jars
+}
+
+// normalizedVersion is the file this tool writes per version. It carries
+// data already filtered for the host it was generated on, so the Rust
+// launcher can build a command line from it directly.
+type normalizedVersion struct {
+	ID               string              `json:"id"`
+	JavaMajorVersion int                 `json:"javaMajorVersion"`
+	JVMArgs          []string            `json:"jvmArgs"`
+	GameArgs         []string            `json:"gameArgs"`
+	Libraries        []normalizedLibrary `json:"libraries"`
+	AssetIndexURL    string              `json:"assetIndexUrl"`
+	AssetIndexID     string              `json:"assetIndexId"`
+}
+
+// filterArguments keeps only the argument values whose rules allow the
+// given runtime context, flattening them into the order the JVM expects.
+func filterArguments(args []versionArgument, ctx rule.RuntimeContext) []string {
+	var out []string
+	for _, a := range args {
+		if !rule.Evaluate(toRules(a.Rules), ctx) {
+			continue
+		}
+		out = append(out, a.Value...)
+	}
+	return out
+}
+
+// resolveLibraries filters libraries (and their natives classifier) down to
+// the ones usable on ctx, turning each into a ready-to-download artifact.
+func resolveLibraries(libs []versionLibrary, ctx rule.RuntimeContext) []normalizedLibrary {
+	var out []normalizedLibrary
+	for _, lib := range libs {
+		if !rule.Evaluate(toRules(lib.Rules), ctx) {
+			continue
+		}
+
+		if lib.Downloads.Artifact != nil {
+			out = append(out, normalizedLibrary{
+				Name: lib.Name,
+				URL:  lib.Downloads.Artifact.URL,
+				SHA1: lib.Downloads.Artifact.SHA1,
+				Size: lib.Downloads.Artifact.Size,
+			})
+		}
+
+		if classifierKey, ok := lib.Natives[ctx.OS]; ok {
+			// Older LWJGL-2 manifests template the classifier key with
+			// "${arch}" (e.g. "natives-windows-${arch}") instead of naming
+			// it outright; substitute the host's bit-width before looking
+			// it up in Classifiers.
+			classifierKey = strings.ReplaceAll(classifierKey, "${arch}", archBits(ctx.Arch))
+			if native, ok := lib.Downloads.Classifiers[classifierKey]; ok {
+				osName := ctx.OS
+				out = append(out, normalizedLibrary{
+					Name:   lib.Name + ":" + classifierKey,
+					URL:    native.URL,
+					SHA1:   native.SHA1,
+					Size:   native.Size,
+					Native: &osName,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// requiresX86_64 reports whether a version only publishes natives for
+// 64-bit under ctx.OS, by resolving libraries once as if the host were
+// 32-bit and once as 64-bit and comparing what comes back.
+func requiresX86_64(v *versionJSON, ctx rule.RuntimeContext) bool {
+	ctx32, ctx64 := ctx, ctx
+	ctx32.Arch, ctx64.Arch = "x86", "x86_64"
+
+	return hasNativeLibrary(resolveLibraries(v.Libraries, ctx64)) && !hasNativeLibrary(resolveLibraries(v.Libraries, ctx32))
+}
+
+// hasNativeLibrary reports whether any resolved library is a native jar.
+func hasNativeLibrary(libs []normalizedLibrary) bool {
+	for _, l := range libs {
+		if l.Native != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchVersionJSON downloads and parses a single version's JSON, as
+// referenced by its `url` field in version_manifest.json.
+func fetchVersionJSON(url string) (*versionJSON, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch version json: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read version json: %w", err)
+	}
+
+	var v versionJSON
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("parse version json: %w", err)
+	}
+	return &v, nil
+}
+
+// normalizeVersion evaluates a raw per-version JSON against ctx and builds
+// the file this tool writes to the cache directory.
+func normalizeVersion(id string, v *versionJSON, ctx rule.RuntimeContext) normalizedVersion {
+	return normalizedVersion{
+		ID:               id,
+		JavaMajorVersion: v.JavaVersion.MajorVersion,
+		JVMArgs:          filterArguments(v.Arguments.JVM, ctx),
+		GameArgs:         filterArguments(v.Arguments.Game, ctx),
+		Libraries:        resolveLibraries(v.Libraries, ctx),
+		AssetIndexURL:    v.AssetIndex.URL,
+		AssetIndexID:     v.AssetIndex.ID,
+	}
+}
+
+// writeVersionCache writes the normalized version data to
+// "<cacheDir>/<id>.json", creating the directory if needed.
+func writeVersionCache(cacheDir, id string, nv normalizedVersion) error {
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(nv, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal normalized version: %w", err)
+	}
+
+	path := filepath.Join(cacheDir, id+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// ingestVersion downloads versionURL, resolves it for the current host, and
+// writes the result into cacheDir.
+func ingestVersion(cacheDir, id, versionURL string) error {
+	v, err := fetchVersionJSON(versionURL)
+	if err != nil {
+		return err
+	}
+
+	nv := normalizeVersion(id, v, currentRuntimeContext())
+	return writeVersionCache(cacheDir, id, nv)
+}
+
+// runIngest walks every version in manifest and writes its normalized JSON
+// into cacheDir, reporting failures without aborting the whole run (a
+// single broken or removed version shouldn't block the rest).
+func runIngest(cacheDir string, manifest Manifest) {
+	fmt.Printf("Ingesting %d versions into %s...\n", len(manifest.Versions), cacheDir)
+
+	failed := 0
+	for _, v := range manifest.Versions {
+		if v.URL == "" {
+			continue
+		}
+		if err := ingestVersion(cacheDir, v.ID, v.URL); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", v.ID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  %s\n", v.ID)
+	}
+
+	if failed > 0 {
+		fmt.Printf("Done, %d version(s) failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("Done")
+}
@@ -4,13 +4,16 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 )
@@ -27,14 +30,18 @@ type VersionInfo struct {
 	ReleaseTime string   // when this version was released
 }
 
+// ManifestVersion is one entry of the manifest's `versions` array.
+type ManifestVersion struct {
+	ID          string `json:"id"`          // version name like "1.20.1"
+	Type        string `json:"type"`        // release, snapshot, etc.
+	URL         string `json:"url"`         // where to fetch this version's own JSON
+	ReleaseTime string `json:"releaseTime"` // ISO date when released
+}
+
 // Manifest represents the JSON structure from Mojang's version list.
 type Manifest struct {
 	Latest   map[string]string `json:"latest"` // latest release and snapshot versions
-	Versions []struct {
-		ID          string `json:"id"`          // version name like "1.20.1"
-		Type        string `json:"type"`        // release, snapshot, etc.
-		ReleaseTime string `json:"releaseTime"` // ISO date when released
-	} `json:"versions"`
+	Versions []ManifestVersion `json:"versions"`
 }
 
 // parseVersion extracts version numbers from Minecraft version strings.
@@ -105,59 +112,15 @@ func getJavaVersion(v string) int {
 	return 8
 }
 
-// needsX86_64 checks if a Minecraft version requires 64-bit architecture.
-// Older versions and some special versions need x86_64.
-func needsX86_64(v string) bool {
-
-	// Old alpha and beta versions need 64-bit
-	if strings.HasPrefix(v, "a") || strings.HasPrefix(v, "b") ||
-		strings.Contains(v, "alpha") || strings.Contains(v, "beta") {
-		return true
-	}
-
-	ver := parseVersion(v)
-
-	// Versions before 1.18 or unparseable versions need 64-bit
-	return ver == nil || ver.Major() < 1 || (ver.Major() == 1 && ver.Minor() < 18)
-}
-
-// getJVMFlags creates the list of Java flags needed to run a specific Minecraft version.
-// Different Java versions and Minecraft versions need different flags.
-func getJVMFlags(javaVer int, mcVer string) []string {
-
-	// Basic flags that all versions need
-	f := []string{
-		"-Djava.library.path=${natives_directory}",         // Tell Java where to find native libraries
-		"-Dminecraft.launcher.brand=${launcher_name}",      // Set launcher name
-		"-Dminecraft.launcher.version=${launcher_version}", // Set launcher version
-		"-cp ${classpath}", // Set the Java classpath
-	}
-
-	// Java 17+ needs special module access flags
-	if javaVer >= 17 {
-		f = append(f, "--add-opens java.base/java.util.jar=ALL-UNNAMED", "--add-opens java.base/java.lang.invoke=ALL-UNNAMED")
-	}
-
-	// Java 21+ needs additional export flags
-	if javaVer >= 21 {
-		f = append(f, "--add-exports java.base/sun.security.util=ALL-UNNAMED", "--add-exports jdk.naming.dns/com.sun.jndi.dns=java.naming")
-	}
-
-	// Set memory and garbage collector based on Minecraft version
-	ver := parseVersion(mcVer)
-	if ver != nil && (ver.Major() > 1 || (ver.Major() == 1 && ver.Minor() >= 13)) {
-		// Minecraft 1.13+ can use more memory and G1 garbage collector
-		f = append(f, "-Xmx2G", "-XX:+UseG1GC")
-	} else {
-		// Older versions use less memory
-		f = append(f, "-Xmx1G")
-	}
-
-	return f
-}
-
 // main function - downloads Minecraft version list and analyzes each version.
 func main() {
+	ingestDir := flag.String("ingest", "", "if set, download every version's own JSON and write normalized per-version files (java, filtered JVM/game args, libraries, asset index) into this cache directory")
+	supportMatrix := flag.Bool("support-matrix", false, "emit a padded list of officially supported versions instead of the summary table")
+	futureReleases := flag.Int("releases", 2, "with -support-matrix, how many future minor releases to predict")
+	majWindow := flag.Int("maj", 0, "with -support-matrix, how many majors ahead of latest a versioned snapshot may be to count as supported")
+	minWindow := flag.Int("min", 1, "with -support-matrix, how many minors ahead of latest a versioned snapshot may be to count as supported")
+	flag.Parse()
+
 	fmt.Println("Minecraft version analyzer")
 
 	// Download the official version list from Mojang
@@ -169,6 +132,19 @@ func main() {
 	var manifest Manifest
 	_ = json.Unmarshal(body, &manifest)
 
+	if *supportMatrix {
+		runSupportMatrix(manifest, time.Now().UTC().Format(time.RFC3339), *futureReleases, *majWindow, *minWindow)
+		return
+	}
+
+	// In --ingest mode, skip the summary table and instead pull each
+	// version's full JSON so the launcher can work off real Mojang
+	// metadata instead of our 8/17/21 heuristic.
+	if *ingestDir != "" {
+		runIngest(*ingestDir, manifest)
+		return
+	}
+
 	// Structure to hold version data for sorting and display
 	type entry struct {
 		id   string          // version name like "1.20.1"
@@ -177,20 +153,28 @@ func main() {
 		time string          // release time for sorting
 	}
 
-	// Analyze each version from the manifest
+	// Analyze each version from the manifest. Java version, 64-bit
+	// requirement and JVM flags all come from that version's own JSON,
+	// evaluated through the rule package, rather than guessed from its
+	// version string.
+	ctx := currentRuntimeContext()
 	var versions []entry
 	for _, v := range manifest.Versions {
-		ver := parseVersion(v.ID)
+		info := VersionInfo{Type: v.Type, ReleaseTime: v.ReleaseTime}
+
+		vj, err := fetchVersionJSON(v.URL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", v.ID, err)
+		} else {
+			info.JavaVersion = vj.JavaVersion.MajorVersion
+			info.JVMFlags = filterArguments(vj.Arguments.JVM, ctx)
+			info.NeedsX86_64 = requiresX86_64(vj, ctx)
+		}
+
 		versions = append(versions, entry{
-			id: v.ID,
-			info: VersionInfo{
-				Type:        v.Type,
-				JavaVersion: getJavaVersion(v.ID),                    // Figure out Java version needed
-				NeedsX86_64: needsX86_64(v.ID),                       // Check if 64-bit is required
-				JVMFlags:    getJVMFlags(getJavaVersion(v.ID), v.ID), // Generate JVM flags
-				ReleaseTime: v.ReleaseTime,
-			},
-			ver:  ver,
+			id:   v.ID,
+			info: info,
+			ver:  parseVersion(v.ID),
 			time: v.ReleaseTime,
 		})
 	}
@@ -0,0 +1,76 @@
+// Package rule evaluates Mojang's `rules` schema, the conditional blocks
+// attached to libraries and to individual JVM/game argument entries in a
+// version JSON. It replaces the version-analyzer's older needsX86_64 and
+// getJVMFlags heuristics, which guessed requirements from the version
+// string instead of reading the rules Mojang actually ships.
+package rule
+
+import "regexp"
+
+// OS is the `rules[].os` predicate: each non-empty field must match for
+// the rule to apply.
+type OS struct {
+	Name    string // "windows", "osx" or "linux"
+	Version string // regex matched against the host OS version string
+	Arch    string // "x86", "x86_64", "arm64", ...
+}
+
+// Rule is one entry of a Mojang `rules` array.
+type Rule struct {
+	Action   string // "allow" or "disallow"
+	OS       *OS
+	Features map[string]bool
+}
+
+// RuntimeContext describes the host (and launch options) rules are
+// evaluated against.
+type RuntimeContext struct {
+	OS        string
+	OSVersion string
+	Arch      string
+	Features  map[string]bool
+}
+
+// Evaluate folds rules left-to-right: the last rule whose predicates match
+// ctx decides the outcome. With no rules at all the default is allow (most
+// libraries and arguments are unconditional); but once a rules list is
+// present, the default flips to disallow until some rule in it matches,
+// mirroring Mojang's own evaluation order.
+func Evaluate(rules []Rule, ctx RuntimeContext) bool {
+	if len(rules) == 0 {
+		return true
+	}
+
+	allowed := false
+	for _, r := range rules {
+		if !matches(r, ctx) {
+			continue
+		}
+		allowed = r.Action == "allow"
+	}
+	return allowed
+}
+
+// matches reports whether a single rule's os/features predicates hold for ctx.
+func matches(r Rule, ctx RuntimeContext) bool {
+	if r.OS != nil {
+		if r.OS.Name != "" && r.OS.Name != ctx.OS {
+			return false
+		}
+		if r.OS.Arch != "" && r.OS.Arch != ctx.Arch {
+			return false
+		}
+		if r.OS.Version != "" {
+			matched, err := regexp.MatchString(r.OS.Version, ctx.OSVersion)
+			if err != nil || !matched {
+				return false
+			}
+		}
+	}
+	for feature, want := range r.Features {
+		if ctx.Features[feature] != want {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+// --support-matrix emits a small, padded list of versions the launcher
+// should treat as officially supported: the current release, a few
+// upcoming ones predicted by extrapolating Mojang's release cadence, and
+// any in-flight snapshots close enough to the current release to matter.
+// The launcher ships this file to gate version-specific feature flags
+// (quick play, chat reporting, ...) without hard-coding a version list.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// supportedVersion is one entry of the --support-matrix output.
+type supportedVersion struct {
+	ID      string `json:"id"`
+	Java    int    `json:"java"`
+	Channel string `json:"channel"` // "release", "predicted" or "snapshot"
+	Expires string `json:"expires"` // RFC 3339
+}
+
+// supportMatrix is the full --support-matrix output document.
+type supportMatrix struct {
+	Generated string             `json:"generated"`
+	Supported []supportedVersion `json:"supported"`
+}
+
+// buildSupportMatrix computes the padded support matrix for manifest: the
+// latest release, the next `futureReleases` minor bumps predicted by
+// continuing the observed one-minor-at-a-time cadence, and any snapshot
+// within `maj` majors and `min` minors of the latest release.
+func buildSupportMatrix(manifest Manifest, futureReleases, maj, min int) (supportMatrix, error) {
+	latestID := manifest.Latest["release"]
+
+	var latestReleaseTime string
+	var latestVer *semver.Version
+	for _, v := range manifest.Versions {
+		if v.ID == latestID {
+			latestReleaseTime = v.ReleaseTime
+			latestVer = parseVersion(v.ID)
+			break
+		}
+	}
+	if latestVer == nil {
+		return supportMatrix{}, fmt.Errorf("latest release %q not found in manifest", latestID)
+	}
+
+	latestTime, err := time.Parse(time.RFC3339, latestReleaseTime)
+	if err != nil {
+		return supportMatrix{}, fmt.Errorf("parse latest release time %q: %w", latestReleaseTime, err)
+	}
+	expires := latestTime.AddDate(0, 0, 90).Format(time.RFC3339)
+
+	var supported []supportedVersion
+	supported = append(supported, supportedVersion{
+		ID:      latestID,
+		Java:    getJavaVersion(latestID),
+		Channel: "release",
+		Expires: expires,
+	})
+
+	for i := 1; i <= futureReleases; i++ {
+		id := fmt.Sprintf("%d.%d", latestVer.Major(), latestVer.Minor()+uint64(i))
+		supported = append(supported, supportedVersion{
+			ID:      id,
+			Java:    getJavaVersion(id),
+			Channel: "predicted",
+			Expires: expires,
+		})
+	}
+
+	for _, v := range manifest.Versions {
+		if v.Type != "snapshot" {
+			continue
+		}
+		if !snapshotInWindow(v, latestVer, latestTime, maj, min) {
+			continue
+		}
+		supported = append(supported, supportedVersion{
+			ID:      v.ID,
+			Java:    getJavaVersion(v.ID),
+			Channel: "snapshot",
+			Expires: expires,
+		})
+	}
+
+	return supportMatrix{Supported: supported}, nil
+}
+
+// snapshotInWindow reports whether a snapshot belongs in the support
+// matrix. Pre-release/RC snapshots embed their target version in the ID
+// (e.g. "1.21.5-rc1") and are compared against latest numerically. Weekly
+// snapshots (e.g. "24w30a") don't carry a version at all, so guessing one
+// would make every historical weekly snapshot match a "next minor" window
+// forever; instead they're bounded by release date, only counting as
+// supported if they're newer than the latest release (i.e. still in flight).
+func snapshotInWindow(v ManifestVersion, latestVer *semver.Version, latestTime time.Time, maj, min int) bool {
+	if ver := parseVersion(v.ID); ver != nil {
+		return withinWindow(ver, latestVer, maj, min)
+	}
+
+	t, err := time.Parse(time.RFC3339, v.ReleaseTime)
+	if err != nil {
+		return false
+	}
+	return t.After(latestTime)
+}
+
+// withinWindow reports whether ver is a version the support matrix should
+// still predict or track: at most maj majors and min minors ahead of
+// latest. Versioned snapshots only ever target an upcoming release, so
+// this is a future-only bound (ver >= latest) rather than a symmetric
+// distance - otherwise a year-old pre-release/RC snapshot that happens to
+// sit within min minors of today's latest would wrongly count as in
+// flight, the same bug the release-date bound above fixes for weekly
+// snapshots.
+func withinWindow(ver, latest *semver.Version, maj, min int) bool {
+	if ver.LessThan(latest) {
+		return false
+	}
+
+	majDiff := int64(ver.Major()) - int64(latest.Major())
+	if majDiff > int64(maj) {
+		return false
+	}
+
+	minDiff := int64(ver.Minor()) - int64(latest.Minor())
+	return minDiff <= int64(min)
+}
+
+// runSupportMatrix builds the support matrix for manifest and writes it to stdout.
+func runSupportMatrix(manifest Manifest, generatedAt string, futureReleases, maj, min int) {
+	matrix, err := buildSupportMatrix(manifest, futureReleases, maj, min)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "build support matrix: %v\n", err)
+		os.Exit(1)
+	}
+	matrix.Generated = generatedAt
+
+	data, err := json.MarshalIndent(matrix, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "marshal support matrix: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
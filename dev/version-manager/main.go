@@ -5,6 +5,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"os"
 	"regexp"
@@ -14,8 +15,12 @@ import (
 	"github.com/Masterminds/semver/v3"
 )
 
-// Path to the Cargo.toml file that contains the project version.
-const cargoToml = "../../Cargo.toml"
+// Path to the Cargo.toml file that contains the project version, and the
+// repository root it lives in (used to resolve commits for pseudo-versions).
+const (
+	cargoToml = "../../Cargo.toml"
+	repoRoot  = "../.."
+)
 
 // This function reads the current version from Cargo.toml file.
 // Returns a semver.Version object or nil if not found.
@@ -116,19 +121,22 @@ func prompt(msg string) string {
 
 // Shows current version and lets user choose how to increment it.
 func main() {
+	force := flag.Bool("force", false, "allow writing a version lower than the current pseudo-version")
+	flag.Parse()
 
 	// Get the current version from Cargo.toml
 	v := getVersion()
 
 	// Show current version and menu options
 	fmt.Printf("Current version: %s\n\n", v)
-	fmt.Println("1. Major increment") // 1.2.3 -> 2.0.0
-	fmt.Println("2. Minor increment") // 1.2.3 -> 1.3.0
-	fmt.Println("3. Patch increment") // 1.2.3 -> 1.2.4
-	fmt.Println("4. Prerelease")      // 1.2.3 -> 1.2.4-alpha.1
-	fmt.Println("5. Exit")
-
-	choice := prompt("Choice [1-5]: ")
+	fmt.Println("1. Major increment")      // 1.2.3 -> 2.0.0
+	fmt.Println("2. Minor increment")      // 1.2.3 -> 1.3.0
+	fmt.Println("3. Patch increment")      // 1.2.3 -> 1.2.4
+	fmt.Println("4. Prerelease")           // 1.2.3 -> 1.2.4-alpha.1
+	fmt.Println("5. Pseudo-version (dev)") // 1.2.3 -> 1.2.4-0.20060102150405-abcdef012345
+	fmt.Println("6. Exit")
+
+	choice := prompt("Choice [1-6]: ")
 	var newV *semver.Version
 
 	// Handle the user's choice
@@ -160,6 +168,20 @@ func main() {
 		updated, _ := newV.SetPrerelease(fmt.Sprintf("%s.%d", preType, num))
 		newV = &updated
 	case "5":
+		// Pseudo-version: stamps the patch bump with the commit it was
+		// built from, so a dev build still sorts ahead of its base tag.
+		commit := prompt("Commit [HEAD]: ")
+		if commit == "" {
+			commit = "HEAD"
+		}
+		temp := v.IncPatch()
+		pv, err := newPseudoVersion(&temp, commit)
+		if err != nil {
+			fmt.Printf("Cannot build pseudo-version: %v\n", err)
+			return
+		}
+		newV = pv
+	case "6":
 		// Exit without making changes
 		return
 	default:
@@ -167,6 +189,18 @@ func main() {
 		return
 	}
 
+	if isPseudoVersion(newV) {
+		if err := validatePseudoVersion(newV); err != nil {
+			fmt.Printf("Refusing to write invalid pseudo-version: %v\n", err)
+			return
+		}
+	}
+
+	if v != nil && isPseudoVersion(v) && newV.LessThan(v) && !*force {
+		fmt.Printf("Refusing to downgrade from pseudo-version %s to %s; pass -force to override\n", v, newV)
+		return
+	}
+
 	// Show the new version and ask for confirmation
 	fmt.Printf("New version: %s\n", newV)
 	if strings.ToLower(prompt("Apply? [y/N]: ")) == "y" {
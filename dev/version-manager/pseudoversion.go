@@ -0,0 +1,93 @@
+// Pseudo-versions for development builds: `X.Y.Z-0.yyyymmddhhmmss-abcdef012345`,
+// the same shape `go get` mints for commits that aren't tagged releases.
+// Unlike a normal prerelease tag, a pseudo-version makes a claim about the
+// repository (which commit, and when it was made) that we can and should
+// check before writing it to Cargo.toml.
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// pseudoVersionRe matches the prerelease component of a pseudo-version:
+// "0.20060102150405-abcdef012345".
+var pseudoVersionRe = regexp.MustCompile(`^0\.(\d{14})-([0-9a-f]{12})$`)
+
+const pseudoTimestampLayout = "20060102150405"
+
+// isPseudoVersion reports whether v's prerelease tag has the pseudo-version shape.
+func isPseudoVersion(v *semver.Version) bool {
+	return pseudoVersionRe.MatchString(v.Prerelease())
+}
+
+// newPseudoVersion builds "base-0.timestamp-hash12" for the given commit,
+// validating the commit before returning it.
+func newPseudoVersion(base *semver.Version, commit string) (*semver.Version, error) {
+	commitTime, fullHash, err := commitMetadata(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := fmt.Sprintf("0.%s-%s", commitTime.UTC().Format(pseudoTimestampLayout), fullHash[:12])
+	updated, err := base.SetPrerelease(tag)
+	if err != nil {
+		return nil, fmt.Errorf("build pseudo-version: %w", err)
+	}
+	return &updated, nil
+}
+
+// validatePseudoVersion re-derives a pseudo-version's commit metadata and
+// confirms it matches what's embedded in v, so a hand-edited or stale
+// pseudo-version can't be written without the repository backing it up.
+func validatePseudoVersion(v *semver.Version) error {
+	m := pseudoVersionRe.FindStringSubmatch(v.Prerelease())
+	if m == nil {
+		return fmt.Errorf("%s is not a pseudo-version", v)
+	}
+	embeddedTimestamp, embeddedHash := m[1], m[2]
+
+	commitTime, fullHash, err := commitMetadata(embeddedHash)
+	if err != nil {
+		return fmt.Errorf("commit %s referenced by pseudo-version not found: %w", embeddedHash, err)
+	}
+
+	if !strings.HasPrefix(fullHash, embeddedHash) {
+		return fmt.Errorf("pseudo-version hash %s does not match resolved commit %s", embeddedHash, fullHash)
+	}
+
+	gotTimestamp := commitTime.UTC().Format(pseudoTimestampLayout)
+	if gotTimestamp != embeddedTimestamp {
+		return fmt.Errorf("pseudo-version timestamp %s does not match commit %s's committer time %s", embeddedTimestamp, fullHash[:12], gotTimestamp)
+	}
+
+	return nil
+}
+
+// commitMetadata resolves a commit-ish to its committer time and full hash
+// via `git log`.
+func commitMetadata(commit string) (time.Time, string, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%cI %H", commit)
+	cmd.Dir = repoRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("git log %s: %w", commit, err)
+	}
+
+	fields := strings.Fields(strings.TrimSpace(string(out)))
+	if len(fields) != 2 {
+		return time.Time{}, "", fmt.Errorf("unexpected git log output: %q", out)
+	}
+
+	committerTime, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("parse committer time %q: %w", fields[0], err)
+	}
+
+	return committerTime, fields[1], nil
+}
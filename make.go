@@ -28,6 +28,10 @@ func main() {
 		runGoVersionAnalyzer()
 	case "go-version-manager":
 		runGoVersionManager()
+	case "go-asset-downloader":
+		runGoAssetDownloader()
+	case "go-java-manager":
+		runGoJavaManager()
 	case "go-build":
 		goBuild()
 	case "go-clean":
@@ -58,6 +62,8 @@ func showHelp() {
 	fmt.Println("  clean                – Clean build artifacts")
 	fmt.Println("  go-version-analyzer  – Run Go version analyzer")
 	fmt.Println("  go-version-manager   – Run Go version manager")
+	fmt.Println("  go-asset-downloader  – Run Go asset downloader")
+	fmt.Println("  go-java-manager      – Run Go java manager")
 	fmt.Println("  go-build             – Run Go projects")
 	fmt.Println("  go-clean             – Run Go clean artifacts")
 }
@@ -115,12 +121,22 @@ func runGoVersionManager() {
 	runGoProject("version-manager")
 }
 
+// Runs the asset downloader tool.
+func runGoAssetDownloader() {
+	runGoProject("asset-downloader")
+}
+
+// Runs the java manager tool.
+func runGoJavaManager() {
+	runGoProject("java-manager")
+}
+
 // Builds all Go projects and creates executable files
 func goBuild() {
 	fmt.Println("Building Go projects...")
 
 	// List of all Go projects to build
-	projects := []string{"version-analyzer", "version-manager"}
+	projects := []string{"version-analyzer", "version-manager", "asset-downloader", "java-manager"}
 
 	for _, project := range projects {
 		projectPath := filepath.Join(goDevDir, project)
@@ -158,7 +174,7 @@ func goClean() {
 	fmt.Println("Cleaning Go artifacts...")
 
 	// List of projects to clean
-	projects := []string{"version-analyzer", "version-manager"}
+	projects := []string{"version-analyzer", "version-manager", "asset-downloader", "java-manager"}
 
 	for _, project := range projects {
 		projectPath := filepath.Join(goDevDir, project)